@@ -1,42 +1,64 @@
 package main
 
 import (
-	"encoding/json" //In this code, for the function "query", we are using the "encoding/json" package to parse the JSON response from the OpenWeatherMap API.
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
-	"net/http" //In this code, for the function "query", we are using the "net/http" package to make HTTP requests to the OpenWeatherMap API.
-	"os"       //In this code, for the function "loadApiConfig", we are using the "os" package to read the API configuration file.
-	"strings"  //In this code, for the function "query", we are using the "strings" package to split the URL path to extract the city name.
+	"net/http"
+	"strconv" // strconv parses the "days", "lat" and "lon" query parameters.
+	"strings" // strings splits the URL path to extract the city name, zip and country.
+
+	"weather-go/providers"
 )
 
-// apiConfig holds the API key for OpenWeatherMap. This struct is used to unmarshal the JSON configuration file.
-// The backtick syntax is used to define struct tags in Go, which provide metadata about the struct fields.
-// The `json:"OpenWeatherMapApiKey"` tag indicates that when this struct is marshaled to or unmarshaled from JSON, the field should be represented with the key "OpenWeatherMapApiKey".
-// This is useful for ensuring that the JSON keys match the expected field names in the struct.
-type apiConfig struct {
-	OpenWeatherMapApiKey string `json:"OpenWeatherMapApiKey"` // means this field is expected to be in the JSON file with the key "OpenWeatherMapApiKey".
+// response is what the /weather/ and /forecast/ handlers send back: the provider-agnostic
+// WeatherData, plus Temp/Unit filled in from whatever ?units= the caller asked for (metric,
+// imperial or kelvin, defaulting to kelvin to match the raw API value).
+type response struct {
+	providers.WeatherData
+	Temp float64 `json:"temp"`
+	Unit string  `json:"unit"`
 }
 
-type weatherData struct { // weatherData struct represents the structure of the weather data returned by the OpenWeatherMap API.
-	Name string `json:"name"`
-	Main struct {
-		Kelvin float64 `json:"temp"`
-	} `json:"main"`
+// withUnits converts d.Main.Kelvin into the requested unit and wraps it into a response.
+// Unrecognised units fall back to kelvin.
+func withUnits(d providers.WeatherData, units string) response {
+	r := response{WeatherData: d}
+	switch units {
+	case "metric":
+		r.Temp = d.Main.Kelvin - 273.15
+		r.Unit = "metric"
+	case "imperial":
+		r.Temp = (d.Main.Kelvin-273.15)*9/5 + 32
+		r.Unit = "imperial"
+	default:
+		r.Temp = d.Main.Kelvin
+		r.Unit = "kelvin"
+	}
+	return r
 }
 
-func loadApiConfig(filename string) (apiConfig, error) { //
-	bytes, err := os.ReadFile(filename) // This line reads the contents of the file specified by `filename` into a byte slice. If the file does not exist or cannot be read, it returns an error.
-	// conerted to bytes because the json.Unmarshal function expects a byte slice as input. json.unmarshal takes a byte slice containing JSON data and unmarshals it into the provided struct type.
-	if err != nil {
-		return apiConfig{}, err
-	}
+// statusCoder is implemented by provider errors that carry the upstream HTTP status code
+// (providers.OpenWeatherError, providers.WeatherAPIError, providers.OpenMeteoError, ...), so
+// writeUpstreamError can map any of them without a per-provider type switch.
+type statusCoder interface {
+	error
+	StatusCode() int
+}
 
-	var c apiConfig
-	err = json.Unmarshal(bytes, &c) //whats hapepening here is that the JSON data read from the file is being unmarshaled into the `apiConfig` struct. The `json.Unmarshal` function takes a byte slice (the contents of the file) and a pointer to a struct (in this case, `&c`) and populates the struct with the data from the JSON.
-	// The `&c` is a pointer to the `apiConfig` struct, which allows `json.Unmarshal` to modify the struct directly with the data it reads from the JSON.If the JSON data does not match the struct fields, `json.Unmarshal` will return an error.
-	if err != nil {
-		return apiConfig{}, err
+// writeUpstreamError maps an error from a Provider to an HTTP response. An error implementing
+// statusCoder carries its own status (401 bad key, 404 unknown city, 429 rate limited, ...);
+// anything else is treated as an opaque internal failure.
+func writeUpstreamError(w http.ResponseWriter, err error) {
+	var sc statusCoder
+	if errors.As(err, &sc) {
+		if status := sc.StatusCode(); status != 0 {
+			http.Error(w, sc.Error(), status)
+			return
+		}
 	}
-	return c, nil
+	http.Error(w, err.Error(), http.StatusInternalServerError)
 }
 
 func hello(w http.ResponseWriter, r *http.Request) { // hello is a simple HTTP handler function that responds with "Hello, World!" when accessed.
@@ -44,32 +66,27 @@ func hello(w http.ResponseWriter, r *http.Request) { // hello is a simple HTTP h
 	w.Write([]byte("Hello, World!\n")) // w.Write writes the byte slice containing "Hello, World!\n" to the response writer, which sends it back to the client.
 }
 
-func query(city string) (weatherData, error) { // query is a function takes a city name as input and queries the OpenWeatherMap API for the current weather data of that city.
-	apiConfig, err := loadApiConfig(".apiConfig")
-	if err != nil {
-		return weatherData{}, err // If there is an error loading the API configuration, it returns an empty weatherData struct and the error.
-	}
-	resp, err := http.Get("http://api.Openweathermap.org/data/2.5/weather?q=" + city + "&appid=" + apiConfig.OpenWeatherMapApiKey) // This line constructs the URL for the API request
-	// using the city name and the API key loaded from the configuration file. It uses the http.Get function to send a GET request to the OpenWeatherMap API.
-	// The URL is constructed by concatenating the base URL of the OpenWeatherMap API with the city name and the API key.
-	// If there is an error making the request, it returns an empty weatherData struct and the error.
+func main() {
+	cfg, err := providers.LoadConfig(".apiConfig")
 	if err != nil {
-		return weatherData{}, err
+		log.Fatal("loading .apiConfig: ", err)
 	}
-	defer resp.Body.Close() // This line ensures that the response body is closed after the function completes, preventing resource leaks.
-	// It defers the closing of the response body until the surrounding function returns.
-	var d weatherData
-	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil { // This line uses the json.NewDecoder function to create a new JSON decoder that reads from the response body.
-		// It then calls the Decode method on the decoder to unmarshal the JSON data into the `weatherData` struct `d`.
-		// If there is an error during decoding (for example, if the JSON response does not match the expected structure), it returns an empty weatherData struct and the error.
-		// The `Decode` method reads the JSON data from the response body and populates the fields of the `weatherData` struct with the corresponding values.
-		// If the decoding is successful, the `weatherData` struct `d` will contain the weather information for the specified city.
-		return weatherData{}, err
+	client := providers.NewClient(providers.DefaultTimeout, providers.DefaultRetryPolicy) // built once, shared by every provider.
+	backends := providers.New(cfg, client)                                                // one Provider per supported backend, keyed by the name clients pass via ?provider=.
+
+	// providerFor resolves which backend a request should use: ?provider= if given, else cfg.DefaultProvider.
+	providerFor := func(r *http.Request) (providers.Provider, error) {
+		name := r.URL.Query().Get("provider")
+		if name == "" {
+			name = cfg.DefaultProvider
+		}
+		p, ok := backends[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown provider %q", name)
+		}
+		return p, nil
 	}
-	return d, nil // If the decoding is successful, it returns the populated `weatherData` struct and a nil error, indicating that the query was successful.
-}
 
-func main() {
 	http.HandleFunc("/hello", hello)
 	http.HandleFunc("/weather/", func(w http.ResponseWriter, r *http.Request) {
 		parts := strings.SplitN(r.URL.Path, "/", 3)
@@ -77,13 +94,109 @@ func main() {
 			http.Error(w, "City name not provided", http.StatusBadRequest)
 			return
 		}
-		data, err := query(parts[2])
+		p, err := providerFor(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var data providers.WeatherData
+		if cp, ok := p.(*providers.CachingProvider); ok && r.URL.Query().Get("nocache") == "1" {
+			data, err = cp.CurrentFresh(r.Context(), parts[2])
+		} else {
+			data, err = p.Current(r.Context(), parts[2])
+		}
+		if err != nil {
+			writeUpstreamError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(withUnits(data, r.URL.Query().Get("units")))
+	})
+
+	http.HandleFunc("/forecast/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.SplitN(r.URL.Path, "/", 3)
+		if len(parts) < 3 || parts[2] == "" {
+			http.Error(w, "City name not provided", http.StatusBadRequest)
+			return
+		}
+
+		days := 5
+		if raw := r.URL.Query().Get("days"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 {
+				http.Error(w, "days must be a positive integer", http.StatusBadRequest)
+				return
+			}
+			days = n
+		}
+
+		p, err := providerFor(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, err := p.Forecast(r.Context(), parts[2], days)
+		if err != nil {
+			writeUpstreamError(w, err)
+			return
+		}
+
+		units := r.URL.Query().Get("units")
+		out := make([]response, len(data))
+		for i, d := range data {
+			out[i] = withUnits(d, units)
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(out)
+	})
+
+	http.HandleFunc("/weather/coords", func(w http.ResponseWriter, r *http.Request) {
+		lat, errLat := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+		lon, errLon := strconv.ParseFloat(r.URL.Query().Get("lon"), 64)
+		if errLat != nil || errLon != nil {
+			http.Error(w, "lat and lon must be provided as floats", http.StatusBadRequest)
+			return
+		}
+
+		p, err := providerFor(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, err := p.ByCoords(r.Context(), lat, lon)
+		if err != nil {
+			writeUpstreamError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(withUnits(data, r.URL.Query().Get("units")))
+	})
+
+	http.HandleFunc("/weather/zip/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.SplitN(r.URL.Path, "/", 4)
+		if len(parts) < 4 || parts[3] == "" {
+			http.Error(w, "zip code not provided", http.StatusBadRequest)
+			return
+		}
+		zip, country, ok := strings.Cut(parts[3], ",")
+		if !ok {
+			http.Error(w, "zip must be in the form {zip},{country}", http.StatusBadRequest)
+			return
+		}
+
+		p, err := providerFor(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, err := p.ByZip(r.Context(), zip, country)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeUpstreamError(w, err)
 			return
 		}
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		json.NewEncoder(w).Encode(data)
+		json.NewEncoder(w).Encode(withUnits(data, r.URL.Query().Get("units")))
 	})
 
 	log.Println("Server listening on http://localhost:8081") // Listening from 8081 host
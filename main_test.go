@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"weather-go/providers"
+)
+
+func TestWithUnits(t *testing.T) {
+	const kelvin = 300.15 // 27°C
+
+	tests := []struct {
+		name     string
+		units    string
+		wantTemp float64
+		wantUnit string
+	}{
+		{"metric", "metric", kelvin - 273.15, "metric"},
+		{"imperial", "imperial", (kelvin-273.15)*9/5 + 32, "imperial"},
+		{"kelvin", "kelvin", kelvin, "kelvin"},
+		{"unknown falls back to kelvin", "bogus", kelvin, "kelvin"},
+		{"empty falls back to kelvin", "", kelvin, "kelvin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d providers.WeatherData
+			d.Main.Kelvin = kelvin
+
+			got := withUnits(d, tt.units)
+			if got.Unit != tt.wantUnit {
+				t.Errorf("Unit = %q, want %q", got.Unit, tt.wantUnit)
+			}
+			const epsilon = 1e-9
+			if diff := got.Temp - tt.wantTemp; diff > epsilon || diff < -epsilon {
+				t.Errorf("Temp = %v, want %v", got.Temp, tt.wantTemp)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cachedWeather is the on-disk shape of one cache entry: the WeatherData plus the time it was
+// fetched, so a later lookup can tell whether the entry is still within TTL.
+type cachedWeather struct {
+	WeatherData WeatherData `json:"weatherData"`
+	FetchedAt   time.Time   `json:"fetchedAt"`
+}
+
+// CachingProvider wraps another Provider, serving Current lookups from disk when a fresh-enough
+// cache entry exists for that city and only falling through to the wrapped Provider on a miss.
+// Concurrent Current calls for the same city are deduplicated so a cache stampede (many clients
+// asking for the same city at once) only reaches the upstream once.
+type CachingProvider struct {
+	Provider
+	Location string
+	TTL      time.Duration
+
+	group singleflightGroup
+}
+
+// NewCachingProvider wraps p so that Current's results are cached as JSON files under location.
+func NewCachingProvider(p Provider, location string, ttl time.Duration) *CachingProvider {
+	return &CachingProvider{Provider: p, Location: location, TTL: ttl}
+}
+
+// cachePath builds the on-disk path for city, rejecting anything that could escape c.Location
+// (a path separator or "..") since city comes straight from the URL path with no validation
+// upstream of here.
+func (c *CachingProvider) cachePath(city string) (string, error) {
+	if city == "" || strings.ContainsAny(city, `/\`) || strings.Contains(city, "..") {
+		return "", fmt.Errorf("providers: invalid cache key %q", city)
+	}
+	return filepath.Join(c.Location, "weather_"+city+".json"), nil
+}
+
+func (c *CachingProvider) readCache(city string) (WeatherData, bool) {
+	path, err := c.cachePath(city)
+	if err != nil {
+		return WeatherData{}, false
+	}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return WeatherData{}, false
+	}
+	var cached cachedWeather
+	if err := json.Unmarshal(bytes, &cached); err != nil {
+		return WeatherData{}, false
+	}
+	if time.Since(cached.FetchedAt) >= c.TTL {
+		return WeatherData{}, false
+	}
+	return cached.WeatherData, true
+}
+
+func (c *CachingProvider) writeCache(city string, data WeatherData) error {
+	path, err := c.cachePath(city)
+	if err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(cachedWeather{WeatherData: data, FetchedAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bytes, 0644)
+}
+
+// Current serves city from the on-disk cache when a fresh entry exists, otherwise queries the
+// wrapped Provider and rewrites the cache.
+func (c *CachingProvider) Current(ctx context.Context, city string) (WeatherData, error) {
+	if data, ok := c.readCache(city); ok {
+		return data, nil
+	}
+	return c.CurrentFresh(ctx, city)
+}
+
+// CurrentFresh bypasses the cache entirely: it always queries the wrapped Provider, then rewrites
+// the cache with the result. The /weather/ handler calls this for ?nocache=1 requests.
+func (c *CachingProvider) CurrentFresh(ctx context.Context, city string) (WeatherData, error) {
+	return c.group.do(city, func() (WeatherData, error) {
+		data, err := c.Provider.Current(ctx, city)
+		if err != nil {
+			return WeatherData{}, err
+		}
+		// A failed cache write shouldn't fail a request that already succeeded upstream.
+		if err := c.writeCache(city, data); err != nil {
+			log.Printf("providers: failed to cache weather for %q: %v", city, err)
+		}
+		return data, nil
+	})
+}
+
+// singleflightGroup deduplicates concurrent calls that share a key, so only one of them actually
+// runs fn; the rest block and receive its result. This is the same shape as
+// golang.org/x/sync/singleflight.Group, hand-rolled here to avoid adding a dependency for it.
+type singleflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+type inflightCall struct {
+	wg   sync.WaitGroup
+	data WeatherData
+	err  error
+}
+
+func (g *singleflightGroup) do(key string, fn func() (WeatherData, error)) (WeatherData, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.data, call.err
+	}
+
+	call := &inflightCall{}
+	call.wg.Add(1)
+	if g.calls == nil {
+		g.calls = map[string]*inflightCall{}
+	}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.data, call.err = fn()
+	call.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return call.data, call.err
+}
@@ -0,0 +1,129 @@
+package providers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeProvider is a Provider double for exercising CachingProvider without hitting the network.
+// It counts calls to Current so tests can assert on cache hits/misses and singleflight dedup.
+type fakeProvider struct {
+	mu    sync.Mutex
+	calls int
+	data  WeatherData
+	err   error
+	delay time.Duration
+}
+
+func (f *fakeProvider) Current(ctx context.Context, city string) (WeatherData, error) {
+	f.mu.Lock()
+	f.calls++
+	f.mu.Unlock()
+	if f.delay > 0 {
+		time.Sleep(f.delay)
+	}
+	return f.data, f.err
+}
+
+func (f *fakeProvider) Forecast(ctx context.Context, city string, days int) ([]WeatherData, error) {
+	return nil, nil
+}
+
+func (f *fakeProvider) ByCoords(ctx context.Context, lat, lon float64) (WeatherData, error) {
+	return WeatherData{}, nil
+}
+
+func (f *fakeProvider) ByZip(ctx context.Context, zip, country string) (WeatherData, error) {
+	return WeatherData{}, nil
+}
+
+func (f *fakeProvider) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestCachingProvider_ServesFromCacheUntilTTLExpires(t *testing.T) {
+	fp := &fakeProvider{data: WeatherData{Name: "London"}}
+	cp := NewCachingProvider(fp, t.TempDir(), 50*time.Millisecond)
+
+	if _, err := cp.Current(context.Background(), "London"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fp.callCount(); got != 1 {
+		t.Fatalf("expected 1 upstream call after cache miss, got %d", got)
+	}
+
+	if _, err := cp.Current(context.Background(), "London"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fp.callCount(); got != 1 {
+		t.Fatalf("expected cache hit to avoid a second upstream call, got %d calls", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := cp.Current(context.Background(), "London"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fp.callCount(); got != 2 {
+		t.Fatalf("expected TTL expiry to trigger a refetch, got %d calls", got)
+	}
+}
+
+func TestCachingProvider_DedupsConcurrentCalls(t *testing.T) {
+	fp := &fakeProvider{data: WeatherData{Name: "Paris"}, delay: 50 * time.Millisecond}
+	cp := NewCachingProvider(fp, t.TempDir(), time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cp.CurrentFresh(context.Background(), "Paris"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := fp.callCount(); got != 1 {
+		t.Fatalf("expected singleflight to collapse concurrent calls into 1 upstream call, got %d", got)
+	}
+}
+
+func TestCachingProvider_WriteFailureDoesNotFailRequest(t *testing.T) {
+	dir := t.TempDir()
+	// Point Location at a plain file rather than a directory, so writing under it fails.
+	blocker := filepath.Join(dir, "not-a-dir")
+	if err := os.WriteFile(blocker, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fp := &fakeProvider{data: WeatherData{Name: "Berlin"}}
+	cp := NewCachingProvider(fp, blocker, time.Minute)
+
+	data, err := cp.CurrentFresh(context.Background(), "Berlin")
+	if err != nil {
+		t.Fatalf("a cache write failure should not fail the request, got: %v", err)
+	}
+	if data.Name != "Berlin" {
+		t.Fatalf("expected upstream data to still be returned, got %+v", data)
+	}
+}
+
+func TestCachingProvider_CachePathRejectsTraversal(t *testing.T) {
+	cp := NewCachingProvider(&fakeProvider{}, t.TempDir(), time.Minute)
+
+	for _, city := range []string{"../evil", "a/../../b", "a/b", "..", ""} {
+		if _, err := cp.cachePath(city); err == nil {
+			t.Errorf("cachePath(%q) = nil error, want an error", city)
+		}
+	}
+	if _, err := cp.cachePath("London"); err != nil {
+		t.Errorf("cachePath(%q) unexpected error: %v", "London", err)
+	}
+}
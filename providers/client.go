@@ -0,0 +1,87 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout is the per-request timeout NewClient uses when none is given.
+const DefaultTimeout = 10 * time.Second
+
+// RetryPolicy controls how Client retries transient failures (network errors and 5xx responses).
+// The nth retry waits BaseBackoff*2^(n-1), plus up to 50% jitter, before trying again.
+type RetryPolicy struct {
+	MaxRetries  int
+	BaseBackoff time.Duration
+}
+
+// DefaultRetryPolicy is a sensible default for NewClient: two retries, starting at 200ms.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 2, BaseBackoff: 200 * time.Millisecond}
+
+// Client is the shared HTTP client every Provider issues upstream requests through. It is built
+// once in main and injected into each Provider, rather than each request reloading configuration
+// from disk the way the original single-provider implementation did.
+type Client struct {
+	HTTP  *http.Client
+	Retry RetryPolicy
+}
+
+// NewClient builds a Client with the given per-request timeout (0 means DefaultTimeout) and retry
+// policy.
+func NewClient(timeout time.Duration, retry RetryPolicy) *Client {
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	return &Client{HTTP: &http.Client{Timeout: timeout}, Retry: retry}
+}
+
+// Get issues a GET request to url, retrying transient network errors and 5xx responses per
+// c.Retry with exponential backoff and jitter. ctx cancels both the retry loop and any request
+// currently in flight, so an upstream client disconnect stops work immediately.
+func (c *Client) Get(ctx context.Context, url string) (*http.Response, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.Retry.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, c.backoff(attempt)); err != nil {
+				return nil, err
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.HTTP.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("upstream returned %s", resp.Status)
+			resp.Body.Close()
+			continue
+		}
+		return resp, nil
+	}
+	return nil, lastErr
+}
+
+// backoff returns BaseBackoff*2^(attempt-1) plus up to 50% jitter.
+func (c *Client) backoff(attempt int) time.Duration {
+	base := c.Retry.BaseBackoff * time.Duration(1<<uint(attempt-1))
+	jitter := time.Duration(rand.Int63n(int64(base)/2 + 1))
+	return base + jitter
+}
+
+// sleep waits for d, returning early with ctx.Err() if ctx is cancelled first.
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,88 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_RetriesOn5xxThenSucceeds(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient(time.Second, RetryPolicy{MaxRetries: 3, BaseBackoff: time.Millisecond})
+	resp, err := c.Get(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts (2 failures + 1 success), got %d", attempts)
+	}
+}
+
+func TestClient_GivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := NewClient(time.Second, RetryPolicy{MaxRetries: 2, BaseBackoff: time.Millisecond})
+	_, err := c.Get(context.Background(), srv.URL)
+	if err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+	if attempts != 3 { // initial attempt + 2 retries
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestClient_DoesNotRetryOn4xx(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := NewClient(time.Second, RetryPolicy{MaxRetries: 3, BaseBackoff: time.Millisecond})
+	resp, err := c.Get(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if attempts != 1 {
+		t.Fatalf("expected a 4xx response to be returned without retrying, got %d attempts", attempts)
+	}
+}
+
+func TestClient_ContextCancelledDuringBackoffStopsRetrying(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := NewClient(time.Second, RetryPolicy{MaxRetries: 5, BaseBackoff: 100 * time.Millisecond})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Get(ctx, srv.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
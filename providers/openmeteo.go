@@ -0,0 +1,199 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// OpenMeteo talks to open-meteo.com, which needs no API key. Unlike the other providers it has
+// no city-name lookup, so Current and Forecast first geocode the city to lat/lon.
+type OpenMeteo struct {
+	Client *Client
+}
+
+// OpenMeteoError reports a non-2xx response from Open-Meteo (either the geocoding or the forecast
+// API). Like WeatherAPIError it skips decoding the body and just reports the status.
+type OpenMeteoError struct {
+	Status int
+}
+
+func (e *OpenMeteoError) Error() string {
+	return fmt.Sprintf("open-meteo: upstream returned status %d", e.Status)
+}
+
+// StatusCode returns the HTTP status Open-Meteo responded with.
+func (e *OpenMeteoError) StatusCode() int {
+	return e.Status
+}
+
+type openMeteoGeocodeResponse struct {
+	Results []struct {
+		Name      string  `json:"name"`
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+		Country   string  `json:"country_code"`
+	} `json:"results"`
+}
+
+type openMeteoForecastResponse struct {
+	Current struct {
+		Temperature float64 `json:"temperature_2m"`
+		Humidity    float64 `json:"relative_humidity_2m"`
+		Pressure    float64 `json:"pressure_msl"`
+		WindSpeed   float64 `json:"wind_speed_10m"`
+		WeatherCode int     `json:"weather_code"`
+	} `json:"current"`
+	Daily struct {
+		Time           []string  `json:"time"`
+		TemperatureMax []float64 `json:"temperature_2m_max"`
+		WindSpeedMax   []float64 `json:"wind_speed_10m_max"`
+		WeatherCode    []int     `json:"weather_code"`
+	} `json:"daily"`
+}
+
+// geocode resolves city to its first matching lat/lon/name/country via Open-Meteo's geocoding API.
+func (p *OpenMeteo) geocode(ctx context.Context, city string) (name string, lat, lon float64, country string, err error) {
+	resp, err := p.Client.Get(ctx, "https://geocoding-api.open-meteo.com/v1/search?count=1&name="+url.QueryEscape(city))
+	if err != nil {
+		return "", 0, 0, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", 0, 0, "", &OpenMeteoError{Status: resp.StatusCode}
+	}
+
+	var g openMeteoGeocodeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&g); err != nil {
+		return "", 0, 0, "", err
+	}
+	if len(g.Results) == 0 {
+		return "", 0, 0, "", fmt.Errorf("open-meteo: no match for city %q", city)
+	}
+	r := g.Results[0]
+	return r.Name, r.Latitude, r.Longitude, r.Country, nil
+}
+
+// Current geocodes city, then asks Open-Meteo's forecast endpoint for current conditions there.
+func (p *OpenMeteo) Current(ctx context.Context, city string) (WeatherData, error) {
+	name, lat, lon, country, err := p.geocode(ctx, city)
+	if err != nil {
+		return WeatherData{}, err
+	}
+	return p.currentAt(ctx, name, lat, lon, country)
+}
+
+// ByCoords asks Open-Meteo's forecast endpoint for current conditions at lat/lon directly; unlike
+// Current, no geocoding round-trip is needed since the caller already has coordinates.
+func (p *OpenMeteo) ByCoords(ctx context.Context, lat, lon float64) (WeatherData, error) {
+	return p.currentAt(ctx, "", lat, lon, "")
+}
+
+// ByZip geocodes zip (together with country, since Open-Meteo's geocoding has no dedicated
+// postal-code lookup) and asks for current conditions there.
+func (p *OpenMeteo) ByZip(ctx context.Context, zip, country string) (WeatherData, error) {
+	name, lat, lon, resolvedCountry, err := p.geocode(ctx, zip+" "+country)
+	if err != nil {
+		return WeatherData{}, err
+	}
+	return p.currentAt(ctx, name, lat, lon, resolvedCountry)
+}
+
+// currentAt asks Open-Meteo's forecast endpoint for current conditions at lat/lon, labelling the
+// result with name/country (already known, or left blank when resolved coordinates have no name).
+func (p *OpenMeteo) currentAt(ctx context.Context, name string, lat, lon float64, country string) (WeatherData, error) {
+	resp, err := p.Client.Get(ctx, fmt.Sprintf(
+		"https://api.open-meteo.com/v1/forecast?latitude=%f&longitude=%f&current=temperature_2m,relative_humidity_2m,pressure_msl,wind_speed_10m,weather_code",
+		lat, lon,
+	))
+	if err != nil {
+		return WeatherData{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return WeatherData{}, &OpenMeteoError{Status: resp.StatusCode}
+	}
+
+	var fr openMeteoForecastResponse
+	if err := json.NewDecoder(resp.Body).Decode(&fr); err != nil {
+		return WeatherData{}, err
+	}
+
+	var d WeatherData
+	d.Name = name
+	d.Main.Kelvin = fr.Current.Temperature + 273.15
+	d.Main.Humidity = fr.Current.Humidity
+	d.Main.Pressure = fr.Current.Pressure
+	d.Wind.Speed = fr.Current.WindSpeed
+	d.Weather = []WeatherCondition{{Description: weatherCodeDescription(fr.Current.WeatherCode)}}
+	d.Coord.Lat = lat
+	d.Coord.Lon = lon
+	d.Sys.Country = country
+	return d, nil
+}
+
+// Forecast geocodes city, then asks Open-Meteo's forecast endpoint for daily highs for days days.
+func (p *OpenMeteo) Forecast(ctx context.Context, city string, days int) ([]WeatherData, error) {
+	name, lat, lon, country, err := p.geocode(ctx, city)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.Client.Get(ctx, fmt.Sprintf(
+		"https://api.open-meteo.com/v1/forecast?latitude=%f&longitude=%f&daily=temperature_2m_max,wind_speed_10m_max,weather_code&forecast_days=%d",
+		lat, lon, days,
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &OpenMeteoError{Status: resp.StatusCode}
+	}
+
+	var fr openMeteoForecastResponse
+	if err := json.NewDecoder(resp.Body).Decode(&fr); err != nil {
+		return nil, err
+	}
+
+	out := make([]WeatherData, 0, len(fr.Daily.Time))
+	for i := range fr.Daily.Time {
+		var d WeatherData
+		d.Name = name
+		d.Main.Kelvin = fr.Daily.TemperatureMax[i] + 273.15
+		d.Wind.Speed = fr.Daily.WindSpeedMax[i]
+		d.Weather = []WeatherCondition{{Description: weatherCodeDescription(fr.Daily.WeatherCode[i])}}
+		d.Coord.Lat = lat
+		d.Coord.Lon = lon
+		d.Sys.Country = country
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// weatherCodeDescription maps a small, common subset of Open-Meteo's WMO weather codes to a
+// human-readable description; OpenWeatherMap and WeatherAPI.com already return text directly.
+func weatherCodeDescription(code int) string {
+	switch {
+	case code == 0:
+		return "clear sky"
+	case code <= 3:
+		return "partly cloudy"
+	case code <= 48:
+		return "fog"
+	case code <= 67:
+		return "rain"
+	case code <= 77:
+		return "snow"
+	case code <= 82:
+		return "rain showers"
+	case code <= 99:
+		return "thunderstorm"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,155 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OpenWeatherMap talks to api.openweathermap.org. This is the original (and still default)
+// backend this module shipped with.
+type OpenWeatherMap struct {
+	ApiKey string
+	Client *Client
+}
+
+// OpenWeatherError is what OpenWeatherMap's API returns in the body of a non-2xx response, e.g.
+// {"cod": 401, "message": "Invalid API key"}. Cod is decoded as json.Number rather than int
+// because OpenWeatherMap is inconsistent about whether it sends it as a string or a number.
+type OpenWeatherError struct {
+	Cod     json.Number `json:"cod"`
+	Message string      `json:"message"`
+}
+
+func (e *OpenWeatherError) Error() string {
+	return fmt.Sprintf("openweathermap: %s (cod %s)", e.Message, e.Cod)
+}
+
+// StatusCode returns the HTTP status OpenWeatherMap reported in Cod, or 0 if it wasn't a valid
+// integer.
+func (e *OpenWeatherError) StatusCode() int {
+	n, err := e.Cod.Int64()
+	if err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+// decodeOpenWeatherError reads resp's body as an OpenWeatherError. Called once resp.StatusCode is
+// already known to be non-2xx.
+func decodeOpenWeatherError(resp *http.Response) error {
+	var e OpenWeatherError
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+		return fmt.Errorf("openweathermap: unexpected response status %s", resp.Status)
+	}
+	return &e
+}
+
+// openWeatherMapForecast is the shape of OpenWeatherMap's /data/2.5/forecast response: a flat
+// list of 3-hour entries, each carrying its own timestamp, spanning up to 5 days.
+type openWeatherMapForecast struct {
+	List []struct {
+		Main struct {
+			Kelvin   float64 `json:"temp"`
+			Humidity float64 `json:"humidity"`
+			Pressure float64 `json:"pressure"`
+		} `json:"main"`
+		Wind struct {
+			Speed float64 `json:"speed"`
+		} `json:"wind"`
+		Weather []WeatherCondition `json:"weather"`
+		DtTxt   string             `json:"dt_txt"` // e.g. "2024-05-01 12:00:00"
+	} `json:"list"`
+	City struct {
+		Name string `json:"name"`
+	} `json:"city"`
+}
+
+// Current queries OpenWeatherMap's current-weather endpoint for city.
+func (p *OpenWeatherMap) Current(ctx context.Context, city string) (WeatherData, error) {
+	return p.currentWeather(ctx, "http://api.Openweathermap.org/data/2.5/weather?q="+url.QueryEscape(city)+"&appid="+p.ApiKey)
+}
+
+// ByCoords queries OpenWeatherMap's current-weather endpoint by latitude/longitude, for callers
+// (GPS-equipped clients, IoT devices) that have coordinates rather than a city name.
+func (p *OpenWeatherMap) ByCoords(ctx context.Context, lat, lon float64) (WeatherData, error) {
+	return p.currentWeather(ctx, fmt.Sprintf("http://api.Openweathermap.org/data/2.5/weather?lat=%f&lon=%f&appid=%s", lat, lon, p.ApiKey))
+}
+
+// ByZip queries OpenWeatherMap's current-weather endpoint by zip/postal code and ISO 3166 country code.
+func (p *OpenWeatherMap) ByZip(ctx context.Context, zip, country string) (WeatherData, error) {
+	return p.currentWeather(ctx, "http://api.Openweathermap.org/data/2.5/weather?zip="+url.QueryEscape(zip)+","+url.QueryEscape(country)+"&appid="+p.ApiKey)
+}
+
+// currentWeather fetches and decodes a current-weather response from url, shared by Current,
+// ByCoords and ByZip (they only differ in how the location is specified in the query string).
+func (p *OpenWeatherMap) currentWeather(ctx context.Context, url string) (WeatherData, error) {
+	resp, err := p.Client.Get(ctx, url)
+	if err != nil {
+		return WeatherData{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return WeatherData{}, decodeOpenWeatherError(resp)
+	}
+
+	var d WeatherData
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		return WeatherData{}, err
+	}
+	return d, nil
+}
+
+// Forecast queries OpenWeatherMap's 5-day/3-hour forecast endpoint for city and reduces it to one
+// WeatherData entry per day (the first entry on record for that day), up to days entries.
+func (p *OpenWeatherMap) Forecast(ctx context.Context, city string, days int) ([]WeatherData, error) {
+	resp, err := p.Client.Get(ctx, "http://api.Openweathermap.org/data/2.5/forecast?q="+url.QueryEscape(city)+"&appid="+p.ApiKey)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, decodeOpenWeatherError(resp)
+	}
+
+	var fr openWeatherMapForecast
+	if err := json.NewDecoder(resp.Body).Decode(&fr); err != nil {
+		return nil, err
+	}
+
+	return reduceForecast(fr, days), nil
+}
+
+// reduceForecast collapses fr's 3-hourly entries down to one WeatherData per calendar day (the
+// first entry on record for that day), up to days entries. Split out of Forecast so the
+// day-dedup/truncation logic can be unit-tested against a fixture without a live HTTP round-trip.
+func reduceForecast(fr openWeatherMapForecast, days int) []WeatherData {
+	var out []WeatherData
+	seenDay := map[string]bool{}
+	for _, entry := range fr.List {
+		day := strings.SplitN(entry.DtTxt, " ", 2)[0] // the date is everything before the space in "2024-05-01 12:00:00".
+		if seenDay[day] {
+			continue
+		}
+		seenDay[day] = true
+
+		var d WeatherData
+		d.Name = fr.City.Name
+		d.Main.Kelvin = entry.Main.Kelvin
+		d.Main.Humidity = entry.Main.Humidity
+		d.Main.Pressure = entry.Main.Pressure
+		d.Wind.Speed = entry.Wind.Speed
+		d.Weather = entry.Weather
+		out = append(out, d)
+
+		if len(out) == days {
+			break
+		}
+	}
+	return out
+}
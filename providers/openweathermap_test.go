@@ -0,0 +1,60 @@
+package providers
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestReduceForecastDedupesByDay(t *testing.T) {
+	const fixture = `{
+		"city": {"name": "London"},
+		"list": [
+			{"main": {"temp": 280.1, "humidity": 70, "pressure": 1008}, "wind": {"speed": 3.1}, "weather": [{"description": "clear sky"}], "dt_txt": "2024-05-01 00:00:00"},
+			{"main": {"temp": 283.4, "humidity": 65, "pressure": 1009}, "wind": {"speed": 2.5}, "weather": [{"description": "few clouds"}], "dt_txt": "2024-05-01 12:00:00"},
+			{"main": {"temp": 281.0, "humidity": 68, "pressure": 1010}, "wind": {"speed": 3.3}, "weather": [{"description": "clear sky"}], "dt_txt": "2024-05-02 00:00:00"},
+			{"main": {"temp": 284.0, "humidity": 60, "pressure": 1011}, "wind": {"speed": 2.0}, "weather": [{"description": "clouds"}], "dt_txt": "2024-05-02 12:00:00"},
+			{"main": {"temp": 279.5, "humidity": 72, "pressure": 1007}, "wind": {"speed": 4.0}, "weather": [{"description": "rain"}], "dt_txt": "2024-05-03 00:00:00"}
+		]
+	}`
+
+	var fr openWeatherMapForecast
+	if err := json.Unmarshal([]byte(fixture), &fr); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+
+	got := reduceForecast(fr, 5)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 days after dedup, got %d: %+v", len(got), got)
+	}
+
+	wantKelvin := []float64{280.1, 281.0, 279.5}
+	for i, d := range got {
+		if d.Name != "London" {
+			t.Errorf("entry %d: Name = %q, want %q", i, d.Name, "London")
+		}
+		if d.Main.Kelvin != wantKelvin[i] {
+			t.Errorf("entry %d: Kelvin = %v, want %v (should keep the day's first entry, not a later one)", i, d.Main.Kelvin, wantKelvin[i])
+		}
+	}
+}
+
+func TestReduceForecastRespectsDaysCap(t *testing.T) {
+	const fixture = `{
+		"city": {"name": "Paris"},
+		"list": [
+			{"main": {"temp": 280.0}, "dt_txt": "2024-05-01 00:00:00"},
+			{"main": {"temp": 281.0}, "dt_txt": "2024-05-02 00:00:00"},
+			{"main": {"temp": 282.0}, "dt_txt": "2024-05-03 00:00:00"}
+		]
+	}`
+
+	var fr openWeatherMapForecast
+	if err := json.Unmarshal([]byte(fixture), &fr); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+
+	got := reduceForecast(fr, 2)
+	if len(got) != 2 {
+		t.Fatalf("expected the days cap to limit the result to 2 entries, got %d", len(got))
+	}
+}
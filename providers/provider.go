@@ -0,0 +1,106 @@
+// Package providers defines the backend-agnostic weather Provider interface and the handful of
+// concrete implementations (OpenWeatherMap, WeatherAPI.com, Open-Meteo) that main.go dispatches
+// to. Callers of the HTTP handlers in main.go never see which backend actually served a response.
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// WeatherCondition mirrors one weather condition entry, e.g. {"description": "light rain"}.
+type WeatherCondition struct {
+	Description string `json:"description"`
+}
+
+// WeatherData is the provider-agnostic shape every Provider normalizes its backend's response
+// into. Temperatures are always in Kelvin here; main.go converts to the caller's requested units.
+type WeatherData struct {
+	Name string `json:"name"`
+	Main struct {
+		Kelvin   float64 `json:"temp"`
+		Humidity float64 `json:"humidity"`
+		Pressure float64 `json:"pressure"`
+	} `json:"main"`
+	Wind struct {
+		Speed float64 `json:"speed"`
+	} `json:"wind"`
+	Weather []WeatherCondition `json:"weather"`
+	Coord   struct {
+		Lon float64 `json:"lon"`
+		Lat float64 `json:"lat"`
+	} `json:"coord"`
+	Sys struct {
+		Country string `json:"country"`
+	} `json:"sys"`
+}
+
+// Provider is the Facade every weather backend sits behind. Its methods return data already
+// normalized into WeatherData, so main.go's handlers don't need to know which backend answered
+// the request. ctx is threaded through to the upstream HTTP call so a client disconnect cancels it.
+type Provider interface {
+	Current(ctx context.Context, city string) (WeatherData, error)
+	Forecast(ctx context.Context, city string, days int) ([]WeatherData, error)
+	ByCoords(ctx context.Context, lat, lon float64) (WeatherData, error)
+	ByZip(ctx context.Context, zip, country string) (WeatherData, error)
+}
+
+// Config holds the per-provider credentials and defaults loaded from .apiConfig. It replaces the
+// single-provider apiConfig that used to hold just an OpenWeatherMap key.
+type Config struct {
+	OpenWeatherMapApiKey string `json:"OpenWeatherMapApiKey"`
+	WeatherApiKey        string `json:"WeatherApiKey"`
+	DefaultProvider      string `json:"DefaultProvider"`
+
+	// CacheLocation is the directory cached responses are written to, and CacheTTL (a
+	// time.ParseDuration string, e.g. "10m") is how long a cached response stays fresh.
+	CacheLocation string `json:"CacheLocation"`
+	CacheTTL      string `json:"CacheTTL"`
+}
+
+// LoadConfig reads and unmarshals the JSON config file at filename. It generalizes the old
+// loadApiConfig, which only knew about a single OpenWeatherMap key.
+func LoadConfig(filename string) (Config, error) {
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var c Config
+	if err := json.Unmarshal(bytes, &c); err != nil {
+		return Config{}, err
+	}
+	if c.DefaultProvider == "" {
+		c.DefaultProvider = "openweathermap"
+	}
+	if c.CacheLocation == "" {
+		c.CacheLocation = "."
+	}
+	if c.CacheTTL == "" {
+		c.CacheTTL = "10m"
+	}
+	return c, nil
+}
+
+// New builds the full set of Providers described by cfg, keyed by the name clients pass via
+// ?provider=. open-meteo is always available since it needs no API key. Every provider is wrapped
+// with an on-disk cache so repeat Current lookups for the same city within cfg.CacheTTL skip the
+// upstream; Forecast, ByCoords and ByZip are not cached.
+// client is shared across all of them, built once by the caller (see NewClient).
+func New(cfg Config, client *Client) map[string]Provider {
+	ttl, err := time.ParseDuration(cfg.CacheTTL)
+	if err != nil {
+		ttl = 10 * time.Minute
+	}
+	cached := func(p Provider) Provider {
+		return NewCachingProvider(p, cfg.CacheLocation, ttl)
+	}
+
+	return map[string]Provider{
+		"openweathermap": cached(&OpenWeatherMap{ApiKey: cfg.OpenWeatherMapApiKey, Client: client}),
+		"weatherapi":     cached(&WeatherAPI{ApiKey: cfg.WeatherApiKey, Client: client}),
+		"open-meteo":     cached(&OpenMeteo{Client: client}),
+	}
+}
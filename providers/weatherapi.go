@@ -0,0 +1,148 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// WeatherAPI talks to api.weatherapi.com, a second backend alongside OpenWeatherMap so a
+// deployment isn't tied to a single upstream's quota or uptime. Its responses have their own
+// shape and are converted into WeatherData here.
+type WeatherAPI struct {
+	ApiKey string
+	Client *Client
+}
+
+// WeatherAPIError reports a non-2xx response from WeatherAPI.com. Unlike OpenWeatherError it
+// doesn't bother decoding the response body (WeatherAPI.com's error shape isn't worth the extra
+// struct for the status codes this module cares about), just the HTTP status.
+type WeatherAPIError struct {
+	Status int
+}
+
+func (e *WeatherAPIError) Error() string {
+	return fmt.Sprintf("weatherapi: upstream returned status %d", e.Status)
+}
+
+// StatusCode returns the HTTP status WeatherAPI.com responded with.
+func (e *WeatherAPIError) StatusCode() int {
+	return e.Status
+}
+
+type weatherApiCurrentResponse struct {
+	Location struct {
+		Name    string  `json:"name"`
+		Lat     float64 `json:"lat"`
+		Lon     float64 `json:"lon"`
+		Country string  `json:"country"`
+	} `json:"location"`
+	Current struct {
+		TempC     float64 `json:"temp_c"`
+		Humidity  float64 `json:"humidity"`
+		PressureM float64 `json:"pressure_mb"`
+		WindKph   float64 `json:"wind_kph"`
+		Condition struct {
+			Text string `json:"text"`
+		} `json:"condition"`
+	} `json:"current"`
+}
+
+type weatherApiForecastResponse struct {
+	Location struct {
+		Name string `json:"name"`
+	} `json:"location"`
+	Forecast struct {
+		Forecastday []struct {
+			Day struct {
+				AvgTempC  float64 `json:"avgtemp_c"`
+				AvgHum    float64 `json:"avghumidity"`
+				MaxWindKp float64 `json:"maxwind_kph"`
+				Condition struct {
+					Text string `json:"text"`
+				} `json:"condition"`
+			} `json:"day"`
+		} `json:"forecastday"`
+	} `json:"forecast"`
+}
+
+// Current queries WeatherAPI.com's current-conditions endpoint for city.
+func (p *WeatherAPI) Current(ctx context.Context, city string) (WeatherData, error) {
+	return p.currentWeather(ctx, url.QueryEscape(city))
+}
+
+// ByCoords queries WeatherAPI.com's current-conditions endpoint by latitude/longitude; the API
+// accepts "lat,lon" directly as its q parameter.
+func (p *WeatherAPI) ByCoords(ctx context.Context, lat, lon float64) (WeatherData, error) {
+	return p.currentWeather(ctx, fmt.Sprintf("%f,%f", lat, lon))
+}
+
+// ByZip queries WeatherAPI.com's current-conditions endpoint by postal code; country is unused
+// since WeatherAPI.com's q parameter takes a bare postal/zip code.
+func (p *WeatherAPI) ByZip(ctx context.Context, zip, country string) (WeatherData, error) {
+	return p.currentWeather(ctx, url.QueryEscape(zip))
+}
+
+// currentWeather fetches and decodes a current-conditions response for the given q parameter,
+// shared by Current, ByCoords and ByZip (they only differ in how the location is specified).
+func (p *WeatherAPI) currentWeather(ctx context.Context, q string) (WeatherData, error) {
+	resp, err := p.Client.Get(ctx, "https://api.weatherapi.com/v1/current.json?key="+p.ApiKey+"&q="+q)
+	if err != nil {
+		return WeatherData{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return WeatherData{}, &WeatherAPIError{Status: resp.StatusCode}
+	}
+
+	var r weatherApiCurrentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return WeatherData{}, err
+	}
+
+	var d WeatherData
+	d.Name = r.Location.Name
+	d.Main.Kelvin = r.Current.TempC + 273.15
+	d.Main.Humidity = r.Current.Humidity
+	d.Main.Pressure = r.Current.PressureM
+	d.Wind.Speed = r.Current.WindKph / 3.6 // kph -> m/s, to match OpenWeatherMap's units.
+	d.Weather = []WeatherCondition{{Description: r.Current.Condition.Text}}
+	d.Coord.Lat = r.Location.Lat
+	d.Coord.Lon = r.Location.Lon
+	d.Sys.Country = r.Location.Country
+	return d, nil
+}
+
+// Forecast queries WeatherAPI.com's forecast endpoint for city, asking it for days days directly
+// (unlike OpenWeatherMap, WeatherAPI.com's free tier already buckets the response by day).
+func (p *WeatherAPI) Forecast(ctx context.Context, city string, days int) ([]WeatherData, error) {
+	resp, err := p.Client.Get(ctx, "https://api.weatherapi.com/v1/forecast.json?key="+p.ApiKey+"&q="+url.QueryEscape(city)+"&days="+strconv.Itoa(days))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &WeatherAPIError{Status: resp.StatusCode}
+	}
+
+	var r weatherApiForecastResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	out := make([]WeatherData, 0, len(r.Forecast.Forecastday))
+	for _, fd := range r.Forecast.Forecastday {
+		var d WeatherData
+		d.Name = r.Location.Name
+		d.Main.Kelvin = fd.Day.AvgTempC + 273.15
+		d.Main.Humidity = fd.Day.AvgHum
+		d.Wind.Speed = fd.Day.MaxWindKp / 3.6
+		d.Weather = []WeatherCondition{{Description: fd.Day.Condition.Text}}
+		out = append(out, d)
+	}
+	return out, nil
+}